@@ -0,0 +1,117 @@
+// Package pokeapi is a small typed client for the public PokeAPI
+// (https://pokeapi.co/docs/v2), covering only the endpoints QuelPoke
+// needs.
+package pokeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"quelpoke/internal/cache"
+)
+
+const defaultBaseURL = "https://pokeapi.co/api/v2"
+
+// doer is satisfied by *http.Client as well as *httpx.Doer, so callers
+// can opt into retries/timeouts/circuit breaking without this package
+// depending on httpx's concrete type.
+type doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client fetches Pokemon resources from a PokeAPI-compatible host.
+type Client struct {
+	baseURL    string
+	userAgent  string
+	httpClient doer
+
+	cache    *cache.Cache
+	cacheTTL time.Duration
+}
+
+// New creates a Client. If baseURL is empty, the public pokeapi.co host
+// is used. If httpClient is nil, http.DefaultClient is used. c may be
+// nil, in which case responses are never cached.
+func New(baseURL, userAgent string, httpClient doer, c *cache.Cache, cacheTTL time.Duration) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: baseURL, userAgent: userAgent, httpClient: httpClient, cache: c, cacheTTL: cacheTTL}
+}
+
+// Pokemon fetches the `/pokemon/{id}` resource.
+func (c *Client) Pokemon(id uint64) (*Pokemon, error) {
+	var p Pokemon
+	if err := c.get(fmt.Sprintf("%s/pokemon/%d", c.baseURL, id), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Species fetches the `/pokemon-species/{id}` resource.
+func (c *Client) Species(id uint64) (*PokemonSpecies, error) {
+	var s PokemonSpecies
+	if err := c.get(fmt.Sprintf("%s/pokemon-species/%d", c.baseURL, id), &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// EvolutionChain fetches an evolution chain resource. url is the full
+// URL as returned by PokemonSpecies.EvolutionChain.URL.
+func (c *Client) EvolutionChain(url string) (*EvolutionChain, error) {
+	var e EvolutionChain
+	if err := c.get(url, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// get issues a GET request against url and decodes the JSON response
+// body into out, consulting and populating the cache (keyed by url) if
+// one was configured.
+func (c *Client) get(url string, out interface{}) error {
+	if c.cache != nil {
+		if body, ok := c.cache.Get(url); ok {
+			return json.Unmarshal(body, out)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("pokeapi: GET %s: unexpected status %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return err
+	}
+
+	if c.cache != nil {
+		c.cache.Add(url, body, c.cacheTTL)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"quelpoke/internal/cache"
+)
+
+func TestClientPokemon(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon/25" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "quelpoke-test" {
+			t.Fatalf("unexpected User-Agent: %q", ua)
+		}
+		_ = json.NewEncoder(w).Encode(Pokemon{
+			ID:   25,
+			Name: "pikachu",
+			Stats: []Stat{
+				{BaseStat: 35, Stat: NamedAPIResource{Name: "hp"}},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, "quelpoke-test", srv.Client(), nil, 0)
+	p, err := client.Pokemon(25)
+	if err != nil {
+		t.Fatalf("Pokemon() error = %v", err)
+	}
+	if p.Name != "pikachu" || len(p.Stats) != 1 || p.Stats[0].BaseStat != 35 {
+		t.Fatalf("unexpected pokemon: %+v", p)
+	}
+}
+
+func TestClientSpecies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(PokemonSpecies{
+			ID:   25,
+			Name: "pikachu",
+			Names: []Name{
+				{Name: "Pikachu", Language: NamedAPIResource{Name: "fr"}},
+			},
+			EvolutionChain: NamedAPIResource{URL: "https://example.invalid/evolution-chain/10"},
+		})
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, "quelpoke-test", srv.Client(), nil, 0)
+	s, err := client.Species(25)
+	if err != nil {
+		t.Fatalf("Species() error = %v", err)
+	}
+	if len(s.Names) != 1 || s.Names[0].Language.Name != "fr" {
+		t.Fatalf("unexpected species: %+v", s)
+	}
+}
+
+func TestClientEvolutionChain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(EvolutionChain{
+			ID: 10,
+			Chain: ChainLink{
+				Species: NamedAPIResource{Name: "pichu"},
+				EvolvesTo: []ChainLink{
+					{Species: NamedAPIResource{Name: "pikachu"}},
+				},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := New("", "quelpoke-test", srv.Client(), nil, 0)
+	chain, err := client.EvolutionChain(srv.URL)
+	if err != nil {
+		t.Fatalf("EvolutionChain() error = %v", err)
+	}
+	if chain.Chain.Species.Name != "pichu" || len(chain.Chain.EvolvesTo) != 1 {
+		t.Fatalf("unexpected chain: %+v", chain)
+	}
+}
+
+func TestClientUsesCache(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(Pokemon{ID: 25, Name: "pikachu"})
+	}))
+	defer srv.Close()
+
+	c := cache.New(0, time.Hour)
+	client := New(srv.URL, "quelpoke-test", srv.Client(), c, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Pokemon(25); err != nil {
+			t.Fatalf("Pokemon() error = %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected a single upstream request, got %d", requests)
+	}
+}
+
+func TestClientGetErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, "quelpoke-test", srv.Client(), nil, 0)
+	if _, err := client.Pokemon(9999); err == nil {
+		t.Fatal("expected error for 404 response, got nil")
+	}
+}
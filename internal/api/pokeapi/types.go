@@ -0,0 +1,60 @@
+package pokeapi
+
+// NamedAPIResource is the common "name + url" reference shape used
+// throughout the PokeAPI (https://pokeapi.co/docs/v2).
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Name is a localized name, as returned in the `names` array of
+// species and other translatable resources.
+type Name struct {
+	Name     string           `json:"name"`
+	Language NamedAPIResource `json:"language"`
+}
+
+// Stat is a single base stat entry on a Pokemon resource.
+type Stat struct {
+	BaseStat int              `json:"base_stat"`
+	Stat     NamedAPIResource `json:"stat"`
+}
+
+// Pokemon is the subset of the `/pokemon/{id}` response QuelPoke uses.
+type Pokemon struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Stats []Stat `json:"stats"`
+}
+
+// PokemonSpecies is the subset of the `/pokemon-species/{id}` response
+// QuelPoke uses.
+type PokemonSpecies struct {
+	ID             uint64           `json:"id"`
+	Name           string           `json:"name"`
+	Names          []Name           `json:"names"`
+	EvolutionChain NamedAPIResource `json:"evolution_chain"`
+}
+
+// ChainLink is one node of an evolution chain tree.
+type ChainLink struct {
+	Species          NamedAPIResource  `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+// EvolutionDetail describes one way a ChainLink's species can evolve
+// into it (e.g. level-up at a given level, or using an item).
+type EvolutionDetail struct {
+	Trigger   NamedAPIResource  `json:"trigger"`
+	MinLevel  int               `json:"min_level"`
+	Item      *NamedAPIResource `json:"item"`
+	HeldItem  *NamedAPIResource `json:"held_item"`
+	TimeOfDay string            `json:"time_of_day"`
+}
+
+// EvolutionChain is the `/evolution-chain/{id}` response.
+type EvolutionChain struct {
+	ID    uint64    `json:"id"`
+	Chain ChainLink `json:"chain"`
+}
@@ -0,0 +1,95 @@
+// Package cache provides a small in-memory, TTL-based cache used to
+// avoid re-fetching PokeAPI responses that rarely change.
+package cache
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// Cache is a thread-safe, in-memory key/value store with per-entry TTL
+// and an optional maximum size. Expired entries are evicted in the
+// background by a reaper goroutine started by New.
+type Cache struct {
+	mu         sync.RWMutex
+	entries    map[string]entry
+	maxEntries int
+
+	hits   uint64
+	misses uint64
+}
+
+// New creates a Cache and starts its background reaper, which wakes up
+// every reapInterval to evict expired entries and log hit/miss metrics.
+// maxEntries <= 0 means unlimited size.
+func New(maxEntries int, reapInterval time.Duration) *Cache {
+	c := &Cache{
+		entries:    make(map[string]entry),
+		maxEntries: maxEntries,
+	}
+	go c.reap(reapInterval)
+	return c
+}
+
+// Get returns the cached value for key, if present and not expired.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Now().After(e.expiresAt) {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	return e.value, true
+}
+
+// Add stores val under key with the given TTL. If the cache is at
+// maxEntries capacity, the new entry is dropped rather than evicting an
+// existing one; the next reap pass will make room once entries expire.
+func (c *Cache) Add(key string, val []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.maxEntries > 0 {
+		if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+			return
+		}
+	}
+	c.entries[key] = entry{value: val, expiresAt: time.Now().Add(ttl)}
+}
+
+// Stats returns the cumulative hit and miss counts since the cache was
+// created.
+func (c *Cache) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
+
+// reap periodically evicts expired entries and logs cache metrics.
+func (c *Cache) reap(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		c.mu.Lock()
+		for key, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		size := len(c.entries)
+		c.mu.Unlock()
+
+		hits, misses := c.Stats()
+		log.Printf("[cache] entries=%d hits=%d misses=%d", size, hits, misses)
+	}
+}
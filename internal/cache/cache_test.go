@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheGetAdd(t *testing.T) {
+	c := New(0, time.Hour)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	c.Add("key", []byte("value"), time.Minute)
+	val, ok := c.Get("key")
+	if !ok || string(val) != "value" {
+		t.Fatalf("Get() = %q, %v, want %q, true", val, ok, "value")
+	}
+
+	hits, misses := c.Stats()
+	if hits != 1 || misses != 1 {
+		t.Fatalf("Stats() = %d, %d, want 1, 1", hits, misses)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	c := New(0, time.Hour)
+	c.Add("key", []byte("value"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("key"); ok {
+		t.Fatal("expected expired entry to be treated as a miss")
+	}
+}
+
+func TestCacheMaxEntries(t *testing.T) {
+	c := New(1, time.Hour)
+	c.Add("a", []byte("1"), time.Minute)
+	c.Add("b", []byte("2"), time.Minute)
+
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected first entry to still be cached")
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected second entry to be dropped at capacity")
+	}
+}
@@ -0,0 +1,45 @@
+package httpx
+
+import (
+	"sync"
+	"time"
+)
+
+// breakerThreshold is the number of consecutive failures to a host
+// that trips its circuit breaker open.
+const breakerThreshold = 5
+
+// breakerCooldown is how long a tripped breaker stays open before
+// allowing requests through again.
+const breakerCooldown = 30 * time.Second
+
+// breaker is a simple per-host circuit breaker: once breakerThreshold
+// consecutive failures are recorded, it rejects requests until
+// breakerCooldown elapses.
+type breaker struct {
+	mu                  sync.Mutex
+	consecutiveFailures int
+	openUntil           time.Time
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Now().After(b.openUntil)
+}
+
+func (b *breaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFailures = 0
+	b.openUntil = time.Time{}
+}
+
+func (b *breaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFailures++
+	if b.consecutiveFailures >= breakerThreshold {
+		b.openUntil = time.Now().Add(breakerCooldown)
+	}
+}
@@ -0,0 +1,126 @@
+// Package httpx wraps an HTTP client with per-request timeouts,
+// retries with backoff, and a per-host circuit breaker, so transient
+// upstream blips don't turn into 500s for our own callers.
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RoundTripper is the minimal interface of the client Doer wraps,
+// satisfied by *http.Client.
+type RoundTripper interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Doer wraps a RoundTripper with timeouts, retries and circuit
+// breaking. It implements RoundTripper itself, so it can be used
+// anywhere a plain *http.Client is, including as the inner client of
+// another Doer (though that would be unusual).
+type Doer struct {
+	inner   RoundTripper
+	timeout time.Duration
+
+	// MaxAttempts and BaseBackoff default to 5 and 200ms; both are
+	// exported so tests can shrink them.
+	MaxAttempts int
+	BaseBackoff time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// New creates a Doer. If inner is nil, http.DefaultClient is used.
+func New(inner RoundTripper, timeout time.Duration) *Doer {
+	if inner == nil {
+		inner = http.DefaultClient
+	}
+	return &Doer{
+		inner:       inner,
+		timeout:     timeout,
+		MaxAttempts: 5,
+		BaseBackoff: 200 * time.Millisecond,
+		breakers:    make(map[string]*breaker),
+	}
+}
+
+// Do executes req, retrying idempotent GETs on timeouts, 429s and
+// 5xx responses with exponential backoff, and short-circuiting hosts
+// that have failed too many times in a row.
+func (d *Doer) Do(req *http.Request) (*http.Response, error) {
+	br := d.breakerFor(req.URL.Host)
+	if !br.allow() {
+		return nil, &APIError{Status: http.StatusServiceUnavailable, Body: "circuit open for " + req.URL.Host}
+	}
+
+	backoff := d.BaseBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= d.MaxAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(req.Context(), d.timeout)
+		resp, err := d.inner.Do(req.Clone(ctx))
+		if err == nil {
+			// Read the body before cancelling ctx, which would otherwise
+			// abort a body that is still arriving from the server.
+			var body []byte
+			body, err = io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		cancel()
+
+		if err != nil {
+			br.recordFailure()
+			lastErr = err
+			if req.Method != http.MethodGet || !isRetryableErr(err) || attempt == d.MaxAttempts {
+				return nil, err
+			}
+			time.Sleep(jitter(backoff))
+			backoff *= 2
+			continue
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			br.recordSuccess()
+			return resp, nil
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		apiErr := &APIError{Status: resp.StatusCode, Body: string(body)}
+
+		if req.Method != http.MethodGet || !isRetryableStatus(resp.StatusCode) || attempt == d.MaxAttempts {
+			if isRetryableStatus(resp.StatusCode) {
+				br.recordFailure()
+			}
+			return nil, apiErr
+		}
+
+		br.recordFailure()
+		lastErr = apiErr
+		wait := retryAfter(resp.Header)
+		if wait == 0 {
+			wait = jitter(backoff)
+		}
+		time.Sleep(wait)
+		backoff *= 2
+	}
+
+	return nil, lastErr
+}
+
+func (d *Doer) breakerFor(host string) *breaker {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	b, ok := d.breakers[host]
+	if !ok {
+		b = &breaker{}
+		d.breakers[host] = b
+	}
+	return b
+}
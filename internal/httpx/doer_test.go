@@ -0,0 +1,108 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestDoer(t *testing.T, srv *httptest.Server) *Doer {
+	t.Helper()
+	d := New(srv.Client(), time.Second)
+	d.BaseBackoff = time.Millisecond
+	return d
+}
+
+func TestDoerRetriesOn5xxThenSucceeds(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	d := newTestDoer(t, srv)
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	resp, err := d.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+	if requests != 3 {
+		t.Fatalf("expected 3 attempts, got %d", requests)
+	}
+}
+
+func TestDoerReturnsAPIErrorOnPermanentStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+	}))
+	defer srv.Close()
+
+	d := newTestDoer(t, srv)
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	_, err := d.Do(req)
+	if err == nil {
+		t.Fatal("expected error for 404 response")
+	}
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Status != http.StatusNotFound {
+		t.Fatalf("apiErr.Status = %d, want %d", apiErr.Status, http.StatusNotFound)
+	}
+}
+
+func TestDoerGivesUpAfterMaxAttempts(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	d := newTestDoer(t, srv)
+	d.MaxAttempts = 3
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if _, err := d.Do(req); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 attempts, got %d", requests)
+	}
+}
+
+func TestDoerOpensCircuitAfterConsecutiveFailures(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	d := newTestDoer(t, srv)
+	d.MaxAttempts = 1
+
+	for i := 0; i < breakerThreshold; i++ {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		if _, err := d.Do(req); err == nil {
+			t.Fatal("expected error from upstream 502")
+		}
+	}
+
+	requestsBeforeOpen := requests
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if _, err := d.Do(req); err == nil {
+		t.Fatal("expected circuit-open error")
+	}
+	if requests != requestsBeforeOpen {
+		t.Fatalf("expected no upstream request once circuit is open, got %d more", requests-requestsBeforeOpen)
+	}
+}
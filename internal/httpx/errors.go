@@ -0,0 +1,17 @@
+package httpx
+
+import "fmt"
+
+// APIError is returned when an upstream request completes with a
+// non-2xx status. Doer.Do also returns one, with Status 503, when a
+// host's circuit breaker is open and no request is made. Callers can
+// type-assert it to inspect Status/Body instead of parsing the error
+// string.
+type APIError struct {
+	Status int
+	Body   string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("httpx: unexpected status %d: %s", e.Status, e.Body)
+}
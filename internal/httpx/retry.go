@@ -0,0 +1,46 @@
+package httpx
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// isRetryableErr reports whether a transport-level error (no response
+// received at all) is worth retrying, i.e. a network timeout.
+func isRetryableErr(err error) bool {
+	var netErr net.Error
+	return err != nil && errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isRetryableStatus reports whether a response status is worth
+// retrying: server errors and rate limiting.
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// jitter returns d plus a random amount in [0, d/2], to avoid retries
+// from concurrent requests synchronizing on the same backoff schedule.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+// retryAfter parses a Retry-After response header (seconds form only,
+// which is what pokeapi.co sends) and returns 0 if absent or invalid.
+func retryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,36 @@
+// Package model holds QuelPoke's response types, shared by the HTML
+// and JSON views so both render exactly the same resolved data.
+package model
+
+// Stat is a single base stat, along with its percentage of the
+// theoretical max base stat (255), used to size the radar chart.
+type Stat struct {
+	Name    string `json:"name"`
+	Base    int    `json:"base"`
+	Percent int    `json:"percent"`
+}
+
+// Evolution is one node of a Pokemon's evolution tree. Trigger,
+// MinLevel, Item, HeldItem and TimeOfDay describe how the species
+// evolves *into* this node; they are empty for the root of the tree.
+type Evolution struct {
+	Name      string      `json:"name"`
+	ID        uint64      `json:"id"`
+	Image     string      `json:"image,omitempty"`
+	EvolvesTo []Evolution `json:"evolves_to,omitempty"`
+	Trigger   string      `json:"trigger,omitempty"`
+	MinLevel  int         `json:"min_level,omitempty"`
+	Item      string      `json:"item,omitempty"`
+	HeldItem  string      `json:"held_item,omitempty"`
+	TimeOfDay string      `json:"time_of_day,omitempty"`
+}
+
+// PokemonResponse is the fully resolved view of a pokemon: the same
+// data backs both the HTML page and the `/api/pokemon` JSON endpoint.
+type PokemonResponse struct {
+	ID          uint64      `json:"id"`
+	Name        string      `json:"name"`
+	Stats       []Stat      `json:"stats"`
+	Evolutions  []Evolution `json:"evolutions"`
+	RadarPoints string      `json:"radar_points"`
+}
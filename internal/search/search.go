@@ -0,0 +1,206 @@
+// Package search builds a small in-memory, prefix/fuzzy search index
+// over Pokemon species names, so users can type a name instead of
+// relying on QuelPoke's usual sha1-of-arbitrary-text lookup.
+package search
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"quelpoke/internal/api/pokeapi"
+)
+
+// fuzzyThreshold is the maximum edit distance still considered a
+// (low-confidence) match.
+const fuzzyThreshold = 2
+
+// Hit is one ranked search result. Name is whichever of the species'
+// localized names actually matched the query, so it's always
+// consistent with Match.Value.
+type Hit struct {
+	ID    uint64 `json:"id"`
+	Name  string `json:"name"`
+	Match Match  `json:"match"`
+}
+
+// Match describes how a Hit's name matched the query, with enough
+// detail for a client to render highlighting.
+type Match struct {
+	Value        string   `json:"value"`
+	MatchLevel   string   `json:"matchLevel"`
+	MatchedWords []string `json:"matchedWords"`
+}
+
+type entry struct {
+	id    uint64
+	names []string
+}
+
+// Index is a ready-to-query, in-memory search index.
+type Index struct {
+	entries []entry
+}
+
+// Build prefetches species 1..max and indexes their French and
+// English names. It fetches through client, so repeated calls are
+// cheap only when client was created with a cache. A species that
+// fails to fetch is logged and skipped rather than aborting the whole
+// build.
+func Build(client *pokeapi.Client, max uint64) *Index {
+	entries := make([]entry, 0, max)
+	for id := uint64(1); id <= max; id++ {
+		species, err := client.Species(id)
+		if err != nil {
+			log.Printf("[WARN] search: failed to fetch species %d, skipping: %v", id, err)
+			continue
+		}
+
+		var names []string
+		if fr := frenchName(*species); fr != "" {
+			names = append(names, fr)
+		}
+		names = append(names, species.Name)
+
+		entries = append(entries, entry{id: id, names: names})
+	}
+	return &Index{entries: entries}
+}
+
+// frenchName returns the species' French localized name, or "".
+func frenchName(species pokeapi.PokemonSpecies) string {
+	for _, n := range species.Names {
+		if n.Language.Name == "fr" {
+			return n.Name
+		}
+	}
+	return ""
+}
+
+// Search ranks the index's entries against q and returns at most limit
+// hits, best match first. limit <= 0 means no limit.
+func (idx *Index) Search(q string, limit int) []Hit {
+	if q == "" {
+		return nil
+	}
+
+	type candidate struct {
+		hit  Hit
+		rank int
+		dist int
+	}
+
+	var candidates []candidate
+	for _, e := range idx.entries {
+		bestName, level, dist, ok := bestMatch(q, e.names)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			hit: Hit{
+				ID:   e.id,
+				Name: bestName,
+				Match: Match{
+					Value:        highlight(bestName, q),
+					MatchLevel:   level,
+					MatchedWords: matchedWords(q, bestName),
+				},
+			},
+			rank: levelRank(level),
+			dist: dist,
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].rank != candidates[j].rank {
+			return candidates[i].rank < candidates[j].rank
+		}
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+		return candidates[i].hit.Name < candidates[j].hit.Name
+	})
+
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	hits := make([]Hit, 0, len(candidates))
+	for _, c := range candidates {
+		hits = append(hits, c.hit)
+	}
+	return hits
+}
+
+// bestMatch returns the best-scoring name among names for query q, and
+// whether any name matched at all.
+func bestMatch(q string, names []string) (name, level string, dist int, ok bool) {
+	bestRank := -1
+	for _, n := range names {
+		l, d, matched := matchName(q, n)
+		if !matched {
+			continue
+		}
+		r := levelRank(l)
+		if bestRank == -1 || r < bestRank || (r == bestRank && d < dist) {
+			bestRank, name, level, dist, ok = r, n, l, d, true
+		}
+	}
+	return name, level, dist, ok
+}
+
+// matchName scores a single name against query q.
+func matchName(q, name string) (level string, dist int, ok bool) {
+	ql, nl := strings.ToLower(q), strings.ToLower(name)
+	switch {
+	case nl == ql:
+		return "exact", 0, true
+	case strings.HasPrefix(nl, ql):
+		return "prefix", 0, true
+	case strings.Contains(nl, ql):
+		return "partial", 0, true
+	}
+
+	d := levenshtein(ql, nl)
+	if d <= fuzzyThreshold {
+		return "fuzzy", d, true
+	}
+	return "", 0, false
+}
+
+func levelRank(level string) int {
+	switch level {
+	case "exact":
+		return 0
+	case "prefix":
+		return 1
+	case "partial":
+		return 2
+	default: // fuzzy
+		return 3
+	}
+}
+
+// highlight wraps the first case-insensitive occurrence of q in name
+// with <em></em>. If q doesn't literally occur (a fuzzy match), name
+// is returned unchanged.
+func highlight(name, q string) string {
+	idx := strings.Index(strings.ToLower(name), strings.ToLower(q))
+	if idx == -1 {
+		return name
+	}
+	return name[:idx] + "<em>" + name[idx:idx+len(q)] + "</em>" + name[idx+len(q):]
+}
+
+// matchedWords returns the whitespace-separated tokens of q that
+// literally occur in name.
+func matchedWords(q, name string) []string {
+	nl := strings.ToLower(name)
+	var words []string
+	for _, w := range strings.Fields(q) {
+		if strings.Contains(nl, strings.ToLower(w)) {
+			words = append(words, w)
+		}
+	}
+	return words
+}
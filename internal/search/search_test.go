@@ -0,0 +1,109 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"quelpoke/internal/api/pokeapi"
+)
+
+func newTestIndex(t *testing.T) *Index {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{
+			ID:   25,
+			Name: "pikachu",
+			Names: []pokeapi.Name{
+				{Name: "Pikachu", Language: pokeapi.NamedAPIResource{Name: "fr"}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	client := pokeapi.New(srv.URL, "quelpoke-test", srv.Client(), nil, 0)
+	return Build(client, 1)
+}
+
+func TestSearchPartialMatch(t *testing.T) {
+	idx := newTestIndex(t)
+
+	hits := idx.Search("chu", 10)
+	if len(hits) != 1 {
+		t.Fatalf("expected 1 hit, got %d", len(hits))
+	}
+	hit := hits[0]
+	if hit.ID != 1 || hit.Name != "Pikachu" {
+		t.Fatalf("unexpected hit: %+v", hit)
+	}
+	if hit.Match.Value != "Pika<em>chu</em>" {
+		t.Fatalf("Match.Value = %q, want %q", hit.Match.Value, "Pika<em>chu</em>")
+	}
+	if hit.Match.MatchLevel != "partial" {
+		t.Fatalf("Match.MatchLevel = %q, want %q", hit.Match.MatchLevel, "partial")
+	}
+	if len(hit.Match.MatchedWords) != 1 || hit.Match.MatchedWords[0] != "chu" {
+		t.Fatalf("Match.MatchedWords = %v", hit.Match.MatchedWords)
+	}
+}
+
+func TestSearchNoMatch(t *testing.T) {
+	idx := newTestIndex(t)
+	if hits := idx.Search("bulbasaur", 10); len(hits) != 0 {
+		t.Fatalf("expected no hits, got %v", hits)
+	}
+}
+
+func TestSearchNameMatchesLocalizedValue(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(pokeapi.PokemonSpecies{
+			ID:   1,
+			Name: "bulbasaur",
+			Names: []pokeapi.Name{
+				{Name: "Bulbizarre", Language: pokeapi.NamedAPIResource{Name: "fr"}},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	client := pokeapi.New(srv.URL, "quelpoke-test", srv.Client(), nil, 0)
+	idx := Build(client, 1)
+
+	hits := idx.Search("bulba", 10)
+	if len(hits) != 1 {
+		t.Fatalf("expected 1 hit, got %d", len(hits))
+	}
+	hit := hits[0]
+	if hit.Name != "bulbasaur" {
+		t.Fatalf("Name = %q, want %q (should match the localized name that actually matched)", hit.Name, "bulbasaur")
+	}
+	if hit.Match.Value != "<em>bulba</em>saur" {
+		t.Fatalf("Match.Value = %q, want %q", hit.Match.Value, "<em>bulba</em>saur")
+	}
+}
+
+func TestSearchFuzzyMatch(t *testing.T) {
+	idx := newTestIndex(t)
+	hits := idx.Search("pikachuu", 10)
+	if len(hits) != 1 || hits[0].Match.MatchLevel != "fuzzy" {
+		t.Fatalf("expected a fuzzy hit, got %+v", hits)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"pikachu", "pikachu", 0},
+		{"pikachu", "pikachuu", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, c := range cases {
+		if got := levenshtein(c.a, c.b); got != c.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
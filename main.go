@@ -5,41 +5,52 @@ import (
 	"embed"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
 	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"quelpoke/internal/api/pokeapi"
+	"quelpoke/internal/cache"
+	"quelpoke/internal/httpx"
+	"quelpoke/internal/model"
+	"quelpoke/internal/search"
 )
 
 //go:embed index.tmpl.html
 var indexTemplateFS embed.FS
 
-type indexTemplateParams struct {
-	PokemonID   uint64
-	PokemonName string
-	Stats       []Stat
-	RadarPoints string
-	Evolutions  []Evolution
-	Name        string
-	Version     string
-}
+// client is the shared PokeAPI client used by all handlers.
+var client *pokeapi.Client
 
-// Stat represents a single base stat from the PokeAPI
-type Stat struct {
-	Name    string
-	Base    int
-	Percent int
-}
+// cacheTTL is the TTL responses are cached for; it is also advertised
+// to HTTP clients via Cache-Control.
+var cacheTTL time.Duration
+
+// searchIndex backs /search and /go. It is built asynchronously at
+// startup (so a slow prefetch doesn't delay ListenAndServe) and is nil
+// until that finishes, in which case both handlers degrade to no
+// results.
+var searchIndex atomic.Pointer[search.Index]
 
-type Evolution struct {
-	Name  string
-	ID    uint64
-	Image string
+// firstGenerationCount is how many species /search and /go consider:
+// the original 151 Pokemon.
+const firstGenerationCount = 151
+
+// indexTemplateParams is the data passed to index.tmpl.html. Query is
+// the raw user input (as opposed to Pokemon.Name, the resolved name).
+type indexTemplateParams struct {
+	Pokemon model.PokemonResponse
+	Query   string
+	Version string
 }
 
 // env return environment value or default if not exists
@@ -50,24 +61,146 @@ func env(name string, def string) string {
 	return def
 }
 
+// envDuration parses the named environment variable as a time.Duration,
+// returning def if unset or invalid.
+func envDuration(name string, def time.Duration) time.Duration {
+	value, err := time.ParseDuration(env(name, ""))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
+// envInt parses the named environment variable as an int, returning def
+// if unset or invalid.
+func envInt(name string, def int) int {
+	value, err := strconv.Atoi(env(name, ""))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
 func main() {
 	addr := env("ADDR", "0.0.0.0")
 	port := env("PORT", "8080")
 	listen := fmt.Sprintf("%s:%s", addr, port)
+	version := env("VERSION", "dev")
+
+	cacheTTL = envDuration("CACHE_TTL", 10*time.Minute)
+	responseCache := cache.New(envInt("CACHE_MAX_ENTRIES", 1000), time.Minute)
+
+	httpTimeout := envDuration("HTTP_TIMEOUT", 5*time.Second)
+	resilientClient := httpx.New(http.DefaultClient, httpTimeout)
+
+	client = pokeapi.New(
+		env("POKEAPI_BASE_URL", ""),
+		fmt.Sprintf("QuelPoke/%s", version),
+		resilientClient,
+		responseCache,
+		cacheTTL,
+	)
+
+	// Prefetching firstGenerationCount species is slow enough (and
+	// flaky enough, against the real PokeAPI) that it shouldn't delay
+	// startup; /search and /go just degrade to no results until it's
+	// done.
+	go func() {
+		searchIndex.Store(search.Build(client, firstGenerationCount))
+	}()
 
 	log.Printf("starting quelpoke app on http://%s", listen)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /", index)
+	mux.HandleFunc("GET /api/pokemon", apiPokemon)
+	mux.HandleFunc("GET /search", searchHandler)
+	mux.HandleFunc("GET /go", goToPokemon)
 	if err := http.ListenAndServe(listen, mux); err != nil {
 		log.Fatal("failed to listen and serve:", err)
 	}
 }
 
-// index renders the index template. It takes name in query parameters
+// errInvalidID marks resolveRequest errors caused by a malformed `id`
+// query parameter, so callers can tell a bad request from an upstream
+// failure and respond with the right status code.
+var errInvalidID = errors.New("invalid id")
+
+// resolveRequest resolves the pokemon to show for r: an explicit `id`
+// query parameter takes priority, falling back to QuelPoke's usual
+// sha1-of-`name` lookup.
+func resolveRequest(r *http.Request) (model.PokemonResponse, error) {
+	if idParam := r.URL.Query().Get("id"); idParam != "" {
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			return model.PokemonResponse{}, fmt.Errorf("%w %q: %v", errInvalidID, idParam, err)
+		}
+		return resolveByID(id)
+	}
+	return resolveByID(pokemonID(r.URL.Query().Get("name"), firstGenerationCount))
+}
+
+// statusForResolveErr maps a resolveRequest error to the HTTP status it
+// should produce: 400 for a malformed request, 500 for anything else.
+func statusForResolveErr(err error) int {
+	if errors.Is(err, errInvalidID) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// resolveByID fetches and assembles everything needed to describe a
+// pokemon (resolved name, stats, radar points, evolutions), shared by
+// both the HTML and JSON views. Stats and evolution lookups that fail
+// are logged and left empty rather than failing the whole request; a
+// failure to resolve the name is returned as an error.
+func resolveByID(id uint64) (model.PokemonResponse, error) {
+	resolvedName, err := pokemonName(id)
+	if err != nil {
+		return model.PokemonResponse{}, err
+	}
+
+	resp := model.PokemonResponse{ID: id, Name: resolvedName}
+
+	if stats, err := pokemonStats(id); err != nil {
+		log.Println("[WARN] failed to fetch pokemon stats:", err)
+	} else {
+		resp.Stats = stats
+	}
+
+	resp.RadarPoints = radarPath(resp.Stats)
+
+	if evs, err := pokemonEvolutions(id); err != nil {
+		log.Println("[WARN] failed to fetch evolution chain:", err)
+	} else {
+		resp.Evolutions = evs
+	}
+
+	return resp, nil
+}
+
+// index renders the index template, or the JSON response if the
+// request's Accept header asks for it. It takes name in query
+// parameters.
 func index(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 	name := r.URL.Query().Get("name")
+
+	resp, err := resolveRequest(r)
+	if err != nil {
+		w.WriteHeader(statusForResolveErr(err))
+		log.Println("[ERR] failed to resolve pokemon:", err)
+		return
+	}
+
+	setCacheControl(w)
+
+	if wantsJSON(r) {
+		writeJSON(w, resp)
+		log.Printf("served json in %s for pokemon id: %d for name: %s", time.Since(start).String(), resp.ID, name)
+		return
+	}
+
 	tmpl, err := template.New("").ParseFS(indexTemplateFS, "*")
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -76,43 +209,88 @@ func index(w http.ResponseWriter, r *http.Request) {
 	}
 
 	params := indexTemplateParams{
-		PokemonID: pokemonID(name, 151),
-		Name:      name,
-		Version:   env("VERSION", "dev"),
+		Pokemon: resp,
+		Query:   name,
+		Version: env("VERSION", "dev"),
 	}
-	params.PokemonName, err = pokemonName(params.PokemonID)
-	if err != nil {
+	if err := tmpl.ExecuteTemplate(w, "index.tmpl.html", params); err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("[ERR] failed to get pokemon name:", err)
+		log.Println("[ERR] failed to execute index template:", err)
 		return
 	}
 
-	// fetch pokemon stats (non-fatal)
-	if stats, err := pokemonStats(params.PokemonID); err != nil {
-		log.Println("[WARN] failed to fetch pokemon stats:", err)
-		params.Stats = nil
-	} else {
-		params.Stats = stats
+	log.Printf("generated page in %s with pokemon id: %d for name: %s", time.Since(start).String(), resp.ID, name)
+}
+
+// apiPokemon handles GET /api/pokemon?name=... and always returns JSON,
+// regardless of the Accept header.
+func apiPokemon(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+
+	resp, err := resolveRequest(r)
+	if err != nil {
+		w.WriteHeader(statusForResolveErr(err))
+		log.Println("[ERR] failed to resolve pokemon:", err)
+		return
 	}
 
-	// compute radar polygon points for stats (for SVG)
-	params.RadarPoints = radarPath(params.Stats)
+	setCacheControl(w)
+	writeJSON(w, resp)
 
-	// fetch evolution chain (non-fatal)
-	if evs, err := pokemonEvolutions(params.PokemonID); err != nil {
-		log.Println("[WARN] failed to fetch evolution chain:", err)
-		params.Evolutions = nil
-	} else {
-		params.Evolutions = evs
+	log.Printf("served json in %s for pokemon id: %d for name: %s", time.Since(start).String(), resp.ID, r.URL.Query().Get("name"))
+}
+
+// searchHandler handles GET /search?q=... returning ranked, highlighted
+// matches against the first-generation Pokemon names.
+func searchHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+
+	var hits []search.Hit
+	if idx := searchIndex.Load(); idx != nil {
+		hits = idx.Search(q, 10)
 	}
 
-	if err := tmpl.ExecuteTemplate(w, "index.tmpl.html", params); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		log.Println("[ERR] failed to execute index template:", err)
-		return
+	setCacheControl(w)
+	writeJSON(w, struct {
+		Hits []search.Hit `json:"hits"`
+	}{Hits: hits})
+}
+
+// goToPokemon handles GET /go?name=... by looking up the best search
+// match for name and redirecting to its real numeric id, instead of
+// QuelPoke's usual sha1-of-arbitrary-text lookup.
+func goToPokemon(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+
+	if idx := searchIndex.Load(); idx != nil {
+		if hits := idx.Search(name, 1); len(hits) > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/?id=%d", hits[0].ID), http.StatusFound)
+			return
+		}
 	}
 
-	log.Printf("generated page in %s with pokemon id: %d for name: %s", time.Since(start).String(), params.PokemonID, params.Name)
+	http.Redirect(w, r, "/?name="+url.QueryEscape(name), http.StatusFound)
+}
+
+// wantsJSON reports whether the request asked for a JSON response via
+// the Accept header.
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+// writeJSON encodes v as the JSON response body.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println("[ERR] failed to encode json response:", err)
+	}
+}
+
+// setCacheControl advertises the same TTL the internal cache uses, so
+// downstream caches (browsers, CDNs) don't re-request more often than
+// we refresh our own data.
+func setCacheControl(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(cacheTTL.Seconds())))
 }
 
 // pokemonID computes the sha1 sum of the name and return
@@ -123,101 +301,47 @@ func pokemonID(name string, m uint64) uint64 {
 	return binary.BigEndian.Uint64(hasher.Sum(nil))%m + 1
 }
 
+// pokemonName returns the French name of the pokemon, falling back to
+// its default (English) name if no French translation is available.
 func pokemonName(id uint64) (string, error) {
-	// Get French name from species endpoint
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%d", id), nil)
-	if err != nil {
-		return "", err
-	}
-
-	resp, err := http.DefaultClient.Do(req)
+	species, err := client.Species(id)
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
-
-	var speciesData struct {
-		Names []struct {
-			Name     string `json:"name"`
-			Language struct {
-				Name string `json:"name"`
-			} `json:"language"`
-		} `json:"names"`
-	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&speciesData); err != nil {
-		return "", err
-	}
-
-	// Find French name
-	for _, n := range speciesData.Names {
+	for _, n := range species.Names {
 		if n.Language.Name == "fr" {
 			return n.Name, nil
 		}
 	}
 
-	// Fallback to default name from pokemon endpoint if French not found
-	req2, err := http.NewRequest("GET", fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%d", id), nil)
-	if err != nil {
-		return "", err
-	}
-
-	resp2, err := http.DefaultClient.Do(req2)
+	pokemon, err := client.Pokemon(id)
 	if err != nil {
 		return "", err
 	}
-	defer resp2.Body.Close()
-
-	var pokemon struct {
-		Name string `json:"name"`
-	}
-
-	if err := json.NewDecoder(resp2.Body).Decode(&pokemon); err != nil {
-		return "", err
-	}
-
 	return pokemon.Name, nil
 }
 
 // pokemonStats fetches base stats for the given pokemon id from pokeapi
-func pokemonStats(id uint64) ([]Stat, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%d", id), nil)
+func pokemonStats(id uint64) ([]model.Stat, error) {
+	pokemon, err := client.Pokemon(id)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var data struct {
-		Stats []struct {
-			Base int `json:"base_stat"`
-			Stat struct {
-				Name string `json:"name"`
-			} `json:"stat"`
-		} `json:"stats"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, err
-	}
-
-	out := make([]Stat, 0, len(data.Stats))
-	for _, s := range data.Stats {
+	out := make([]model.Stat, 0, len(pokemon.Stats))
+	for _, s := range pokemon.Stats {
 		percent := 0
-		if s.Base > 0 {
-			percent = s.Base * 100 / 255
+		if s.BaseStat > 0 {
+			percent = s.BaseStat * 100 / 255
 		}
-		out = append(out, Stat{Name: s.Stat.Name, Base: s.Base, Percent: percent})
+		out = append(out, model.Stat{Name: s.Stat.Name, Base: s.BaseStat, Percent: percent})
 	}
 	return out, nil
 }
 
 // radarPath builds an SVG points string for a polygon representing the stats
-func radarPath(stats []Stat) string {
+func radarPath(stats []model.Stat) string {
 	if len(stats) == 0 {
 		return ""
 	}
@@ -235,106 +359,63 @@ func radarPath(stats []Stat) string {
 	return strings.Join(parts, " ")
 }
 
-// pokemonEvolutions fetches the evolution chain (simple first-branch traversal)
-func pokemonEvolutions(id uint64) ([]Evolution, error) {
-	// fetch species to get evolution chain url
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://pokeapi.co/api/v2/pokemon-species/%d", id), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := http.DefaultClient.Do(req)
+// pokemonEvolutions fetches the full evolution tree for the given
+// pokemon id. The returned slice always holds a single element: the
+// base form of the chain, with its branches nested under EvolvesTo.
+func pokemonEvolutions(id uint64) ([]model.Evolution, error) {
+	species, err := client.Species(id)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	var speciesData struct {
-		EvolutionChain struct {
-			URL string `json:"url"`
-		} `json:"evolution_chain"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&speciesData); err != nil {
-		return nil, err
-	}
-
-	// fetch evolution chain
-	req2, err := http.NewRequest("GET", speciesData.EvolutionChain.URL, nil)
+	chain, err := client.EvolutionChain(species.EvolutionChain.URL)
 	if err != nil {
 		return nil, err
 	}
-	resp2, err := http.DefaultClient.Do(req2)
-	if err != nil {
-		return nil, err
-	}
-	defer resp2.Body.Close()
-
-	var chainData struct {
-		Chain struct {
-			Species struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"species"`
-			EvolvesTo []struct {
-				Species struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"species"`
-				EvolvesTo []interface{} `json:"evolves_to"`
-			} `json:"evolves_to"`
-		} `json:"chain"`
-	}
-	if err := json.NewDecoder(resp2.Body).Decode(&chainData); err != nil {
-		return nil, err
-	}
 
-	// traverse first-branch path
-	var out []Evolution
-	node := chainData.Chain
-	for {
-		sp := node.Species
-		// extract id from url (last part)
-		u := strings.TrimRight(sp.URL, "/")
-		parts := strings.Split(u, "/")
-		var spid uint64
-		if len(parts) > 0 {
-			if v, err := strconv.ParseUint(parts[len(parts)-1], 10, 64); err == nil {
-				spid = v
-			}
-		}
-		img := ""
-		if spid > 0 {
-			img = fmt.Sprintf("https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/other/official-artwork/%d.png", spid)
-		}
-		out = append(out, Evolution{Name: sp.Name, ID: spid, Image: img})
+	return []model.Evolution{buildEvolution(chain.Chain)}, nil
+}
 
-		if len(node.EvolvesTo) == 0 {
-			break
-		}
-		// move to first evolves_to
-		// decode node.EvolvesTo[0] into the same structure by re-marshallingâ€”simpler is to unmarshal into a temporary struct
-		var next struct {
-			Species struct {
-				Name string `json:"name"`
-				URL  string `json:"url"`
-			} `json:"species"`
-			EvolvesTo []struct {
-				Species struct {
-					Name string `json:"name"`
-					URL  string `json:"url"`
-				} `json:"species"`
-				EvolvesTo []interface{} `json:"evolves_to"`
-			} `json:"evolves_to"`
-		}
-		// marshal/unmarshal via map is avoided; use a quick json roundtrip from raw interface
-		// easier: build next from node.EvolvesTo[0] by converting with json
-		b, _ := json.Marshal(node.EvolvesTo[0])
-		if err := json.Unmarshal(b, &next); err != nil {
-			break
+// buildEvolution recursively converts a pokeapi.ChainLink into an
+// Evolution tree, capturing how each child evolves from its parent.
+func buildEvolution(node pokeapi.ChainLink) model.Evolution {
+	ev := model.Evolution{Name: node.Species.Name, ID: speciesID(node.Species.URL)}
+	if ev.ID > 0 {
+		ev.Image = fmt.Sprintf("https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/other/official-artwork/%d.png", ev.ID)
+	}
+
+	ev.EvolvesTo = make([]model.Evolution, 0, len(node.EvolvesTo))
+	for _, child := range node.EvolvesTo {
+		childEv := buildEvolution(child)
+		if len(child.EvolutionDetails) > 0 {
+			d := child.EvolutionDetails[0]
+			childEv.Trigger = d.Trigger.Name
+			childEv.MinLevel = d.MinLevel
+			if d.Item != nil {
+				childEv.Item = d.Item.Name
+			}
+			if d.HeldItem != nil {
+				childEv.HeldItem = d.HeldItem.Name
+			}
+			childEv.TimeOfDay = d.TimeOfDay
 		}
-		// assign node = next (need to convert shape)
-		node.Species = next.Species
-		node.EvolvesTo = next.EvolvesTo
+		ev.EvolvesTo = append(ev.EvolvesTo, childEv)
 	}
 
-	return out, nil
+	return ev
+}
+
+// speciesID extracts the numeric id from a PokeAPI resource URL such as
+// "https://pokeapi.co/api/v2/pokemon-species/25/".
+func speciesID(url string) uint64 {
+	u := strings.TrimRight(url, "/")
+	parts := strings.Split(u, "/")
+	if len(parts) == 0 {
+		return 0
+	}
+	id, err := strconv.ParseUint(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
 }